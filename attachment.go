@@ -0,0 +1,177 @@
+package discordhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Attachment represents a single file to upload alongside a webhook execute
+// request (ExecuteWithAttachments, ExecuteInThread). ID identifies the
+// attachment's `files[n]` multipart part and is mirrored into the
+// `payload_json.attachments` descriptor so it can be referenced from an embed's
+// image/thumbnail URL as `attachment://<filename>`. EditMessage does not take
+// Attachment values and uploads no files; existing attachments are kept there
+// through WebhookMessageEditParams.Attachments.
+type Attachment struct {
+	// ID - identifies this attachment, matching the `files[ID]` multipart field name
+	ID int
+	// Filename - filename of the file, also used for `attachment://<filename>` references
+	Filename string
+	// Description - alt text for the file
+	Description string
+	// ContentType - MIME type of the file; if empty, the file part is sent as application/octet-stream
+	ContentType string
+	// Reader - file content to upload; if nil, only the descriptor is sent and no file part is written
+	Reader io.Reader
+}
+
+// MessageAttachment is the attachment descriptor carried in `payload_json.attachments`,
+// describing an uploaded file (or, when editing a message, an existing one to keep).
+// https://discord.com/developers/docs/resources/channel#attachment-object
+type MessageAttachment struct {
+	// ID - attachment id, matching the `files[n]` multipart part it belongs to
+	ID int `json:"id"`
+	// Filename - name of the attached file
+	Filename string `json:"filename,omitempty"`
+	// Description - alt text for the file
+	Description string `json:"description,omitempty"`
+	// ContentType - the attachment's media type
+	ContentType string `json:"content_type,omitempty"`
+	// Size - size of the file in bytes
+	Size int `json:"size,omitempty"`
+	// URL - source url of the file
+	URL string `json:"url,omitempty"`
+	// ProxyURL - a proxied url of the file
+	ProxyURL string `json:"proxy_url,omitempty"`
+}
+
+// ExecuteWithAttachments - executes webhook with zero or more file attachments,
+// wiring their descriptors into the `attachments` array of the sent payload (a
+// copy of wep; wep itself is not modified) so they can be referenced from
+// embed image/thumbnail URLs via `attachment://<filename>`. If `wait` was set to
+// `true`, then the response will be expected and parsed in `msg`.
+// Return message, error
+func (wa *WebhookAPI) ExecuteWithAttachments(ctx context.Context, wep *WebhookExecuteParams, attachments []Attachment) (*Message, error) {
+	return wa.execute(ctx, wa.URL, wep, attachments)
+}
+
+// execute posts wep and attachments to u, the shared implementation behind
+// ExecuteWithAttachments and ExecuteInThread.
+func (wa *WebhookAPI) execute(ctx context.Context, u *url.URL, wep *WebhookExecuteParams, attachments []Attachment) (*Message, error) {
+	bodyBuf := bytes.NewBuffer([]byte{})
+
+	mw := multipart.NewWriter(bodyBuf)
+
+	// Marshal a shallow copy rather than wep itself: wep is often a template a
+	// caller reuses (and posts concurrently), so writing Attachments into it
+	// directly would race.
+	payload := WebhookExecuteParams{}
+	if wep != nil {
+		payload = *wep
+	}
+
+	payload.Attachments = make([]*MessageAttachment, 0, len(attachments))
+	for _, att := range attachments {
+		payload.Attachments = append(payload.Attachments, &MessageAttachment{
+			ID:          att.ID,
+			Filename:    att.Filename,
+			Description: att.Description,
+			ContentType: att.ContentType,
+		})
+	}
+
+	payloadPart, err := mw.CreateFormField("payload_json")
+	if err != nil {
+		return nil, err
+	}
+
+	err = wa.encoder().Encode(payloadPart, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, att := range attachments {
+		if att.Reader == nil {
+			continue
+		}
+
+		filePart, err := createAttachmentFormFile(mw, att.ID, att.Filename, att.ContentType)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.Copy(filePart, att.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = mw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    u,
+		Header: http.Header{
+			"Content-Type": {mw.FormDataContentType()},
+		},
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, bodyBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != 204 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(b))
+	}
+
+	if wa.Wait {
+		msg := new(Message)
+		err = jsoniter.NewDecoder(res.Body).Decode(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return msg, nil
+	}
+
+	return nil, nil
+}
+
+// createAttachmentFormFile creates a `files[id]` multipart part, honoring an
+// explicit content type rather than always falling back to the Go default.
+func createAttachmentFormFile(mw *multipart.Writer, id int, filename string, contentType string) (io.Writer, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%s]"; filename="%s"`, strconv.Itoa(id), filename))
+	h.Set("Content-Type", contentType)
+
+	return mw.CreatePart(h)
+}
@@ -0,0 +1,162 @@
+package discordhook
+
+import (
+	"github.com/andersfylling/snowflake"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ComponentType identifies the concrete shape of a Component.
+// https://discord.com/developers/docs/interactions/message-components#component-object-component-types
+type ComponentType int
+
+const (
+	// ComponentTypeActionRow - a container for other components
+	ComponentTypeActionRow ComponentType = 1
+	// ComponentTypeButton - a clickable button
+	ComponentTypeButton ComponentType = 2
+	// ComponentTypeSelectMenu - a select menu for picking from choices
+	ComponentTypeSelectMenu ComponentType = 3
+)
+
+// Component is implemented by every message component (ActionRow, Button,
+// SelectMenu, ...). Each concrete type marshals itself into the discriminated
+// JSON shape Discord expects, tagged by Type().
+// https://discord.com/developers/docs/interactions/message-components
+type Component interface {
+	// Type reports the component's ComponentType discriminator
+	Type() ComponentType
+}
+
+// ActionRow is a top-level container component holding up to 5 Buttons, or a
+// single SelectMenu.
+type ActionRow struct {
+	// Components - the components contained within this action row
+	Components []Component `json:"components,omitempty"`
+}
+
+// Type implements Component.
+func (ar *ActionRow) Type() ComponentType {
+	return ComponentTypeActionRow
+}
+
+// MarshalJSON implements json.Marshaler, injecting the `type` discriminator.
+func (ar *ActionRow) MarshalJSON() ([]byte, error) {
+	type alias ActionRow
+	return jsoniter.Marshal(&struct {
+		Type ComponentType `json:"type"`
+		*alias
+	}{
+		Type:  ComponentTypeActionRow,
+		alias: (*alias)(ar),
+	})
+}
+
+// ButtonStyle controls the color and behavior of a Button.
+// https://discord.com/developers/docs/interactions/message-components#button-object-button-styles
+type ButtonStyle int
+
+const (
+	// ButtonStylePrimary - blurple
+	ButtonStylePrimary ButtonStyle = 1
+	// ButtonStyleSecondary - grey
+	ButtonStyleSecondary ButtonStyle = 2
+	// ButtonStyleSuccess - green
+	ButtonStyleSuccess ButtonStyle = 3
+	// ButtonStyleDanger - red
+	ButtonStyleDanger ButtonStyle = 4
+	// ButtonStyleLink - grey, navigates to a URL instead of firing an interaction
+	ButtonStyleLink ButtonStyle = 5
+)
+
+// ComponentEmoji is the partial emoji object accepted on Buttons and SelectOptions.
+type ComponentEmoji struct {
+	// ID (snowflake) - id of a custom emoji
+	ID snowflake.Snowflake `json:"id,omitempty"`
+	// Name - name of the emoji, or the unicode character for a standard emoji
+	Name string `json:"name,omitempty"`
+	// Animated - whether this emoji is animated
+	Animated bool `json:"animated,omitempty"`
+}
+
+// Button is a clickable component, placed inside an ActionRow.
+// https://discord.com/developers/docs/interactions/message-components#button-object-button-structure
+type Button struct {
+	// Style - one of the ButtonStyle values [Required]
+	Style ButtonStyle `json:"style"`
+	// Label - text shown on the button
+	Label string `json:"label,omitempty"`
+	// Emoji - emoji shown on the button
+	Emoji *ComponentEmoji `json:"emoji,omitempty"`
+	// CustomID - developer-defined id, fired back on interaction [Required unless Style is ButtonStyleLink]
+	CustomID string `json:"custom_id,omitempty"`
+	// URL - url to navigate to [Required if Style is ButtonStyleLink]
+	URL string `json:"url,omitempty"`
+	// Disabled - whether the button is disabled
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Type implements Component.
+func (b *Button) Type() ComponentType {
+	return ComponentTypeButton
+}
+
+// MarshalJSON implements json.Marshaler, injecting the `type` discriminator.
+func (b *Button) MarshalJSON() ([]byte, error) {
+	type alias Button
+	return jsoniter.Marshal(&struct {
+		Type ComponentType `json:"type"`
+		*alias
+	}{
+		Type:  ComponentTypeButton,
+		alias: (*alias)(b),
+	})
+}
+
+// SelectOption is a single choice within a SelectMenu.
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-option-structure
+type SelectOption struct {
+	// Label - user-facing name of the option [Required]
+	Label string `json:"label"`
+	// Value - developer-defined value, fired back on interaction [Required]
+	Value string `json:"value"`
+	// Description - additional description of the option
+	Description string `json:"description,omitempty"`
+	// Emoji - emoji shown with the option
+	Emoji *ComponentEmoji `json:"emoji,omitempty"`
+	// Default - whether this option is selected by default
+	Default bool `json:"default,omitempty"`
+}
+
+// SelectMenu lets a user pick one or more choices, placed inside an ActionRow.
+// https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
+type SelectMenu struct {
+	// CustomID - developer-defined id, fired back on interaction [Required]
+	CustomID string `json:"custom_id"`
+	// Options - the choices in the select, max 25 [Required]
+	Options []*SelectOption `json:"options,omitempty"`
+	// Placeholder - custom placeholder text shown when nothing is selected
+	Placeholder string `json:"placeholder,omitempty"`
+	// MinValues - minimum number of items that must be chosen; default 1
+	MinValues *int `json:"min_values,omitempty"`
+	// MaxValues - maximum number of items that can be chosen; default 1
+	MaxValues *int `json:"max_values,omitempty"`
+	// Disabled - whether the select menu is disabled
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Type implements Component.
+func (s *SelectMenu) Type() ComponentType {
+	return ComponentTypeSelectMenu
+}
+
+// MarshalJSON implements json.Marshaler, injecting the `type` discriminator.
+func (s *SelectMenu) MarshalJSON() ([]byte, error) {
+	type alias SelectMenu
+	return jsoniter.Marshal(&struct {
+		Type ComponentType `json:"type"`
+		*alias
+	}{
+		Type:  ComponentTypeSelectMenu,
+		alias: (*alias)(s),
+	})
+}
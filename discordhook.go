@@ -6,7 +6,6 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -23,6 +22,13 @@ type WebhookAPI struct {
 	Client *http.Client
 	// Wait - if `true`, then the response will be expected and parsed in `msg`.
 	Wait bool
+	// Limiter tracks Discord's per-webhook rate limit bucket and blocks/retries
+	// requests around it. NewWebhookAPI enables it; set to `nil` to disable and
+	// handle rate limiting yourself.
+	Limiter *Limiter
+	// Encoder - encodes outgoing JSON payloads. Defaults to a jsoniter-based
+	// encoder when left `nil`.
+	Encoder DataEncoder
 }
 
 // NewWebhookAPI creates WebhookExecuter (https://discord.com/api/webhooks/WEBHOOK_ID/WEBHOOK_TOKEN).
@@ -39,12 +45,64 @@ func NewWebhookAPI(webhookID snowflake.Snowflake, webhookToken string, wait bool
 	}
 
 	return &WebhookAPI{
-		URL:    u,
-		Client: client,
-		Wait:   wait,
+		URL:     u,
+		Client:  client,
+		Wait:    wait,
+		Limiter: NewLimiter(),
 	}, nil
 }
 
+// maxRetries429 caps how many times do will retry a request after a 429
+// response before giving up, so a misbehaving or permanently rate-limited
+// endpoint can't wedge a caller in an infinite retry loop.
+const maxRetries429 = 10
+
+// ErrTooManyRetries is returned by do once a request has been retried
+// maxRetries429 times after consecutive 429 responses.
+var ErrTooManyRetries = errors.New("discordhook: exceeded max retries after repeated 429 responses")
+
+// do sends req, pre-acquiring wa.Limiter and transparently retrying 429 responses
+// using `Retry-After`, up to maxRetries429 times. payload is the raw request body
+// bytes (nil for bodyless requests) so the body reader can be rebuilt on every
+// retry attempt.
+func (wa *WebhookAPI) do(ctx context.Context, req *http.Request, payload []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if payload != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		}
+
+		if err := wa.Limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err := wa.Client.Do(req)
+		if err != nil {
+			wa.Limiter.release()
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusTooManyRequests && wa.Limiter != nil {
+			wa.Limiter.release()
+			wait := wa.Limiter.handle429(res.Header)
+			res.Body.Close()
+
+			if attempt >= maxRetries429 {
+				return nil, ErrTooManyRetries
+			}
+
+			if err := sleepCtx(ctx, wait); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		wa.Limiter.update(res.Header)
+
+		return res, nil
+	}
+}
+
 // WebhookExecuteParams represents webhook params payload structure
 // https://discord.com/developers/docs/resources/webhook#execute-webhook-jsonform-params
 type WebhookExecuteParams struct {
@@ -60,80 +118,24 @@ type WebhookExecuteParams struct {
 	Embeds []*Embed `json:"embeds,omitempty"`
 	// AllowedMentions - allowed mentions for the message [Required: false]
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Attachments - descriptors for uploaded files; the execute methods replace this
+	// with descriptors built from their attachments in the payload they send
+	Attachments []*MessageAttachment `json:"attachments,omitempty"`
+	// Components - message components such as buttons and select menus [Required: false]
+	Components []Component `json:"components,omitempty"`
+	// ThreadName - name for a new thread to create on a forum channel [Required: false]
+	ThreadName string `json:"thread_name,omitempty"`
 }
 
 // Execute - executes webhook. If `wait` was set to `true`, then the response will be expected and parsed in `msg`.
+// This is a thin wrapper around ExecuteWithAttachments for the common single-file case.
 // Return message, http status code, error
 func (wa *WebhookAPI) Execute(ctx context.Context, wep *WebhookExecuteParams, file io.Reader, filename string) (*Message, error) {
-	bodyBuf := bytes.NewBuffer([]byte{})
-
-	mw := multipart.NewWriter(bodyBuf)
-
-	payloadPart, err := mw.CreateFormField("payload_json")
-	if err != nil {
-		return nil, err
-	}
-
-	err = jsoniter.NewEncoder(payloadPart).Encode(wep)
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return wa.ExecuteWithAttachments(ctx, wep, nil)
 	}
 
-	if file != nil {
-		filePart, err := mw.CreateFormFile("file", filename)
-		if err != nil {
-			return nil, err
-		}
-		_, err = io.Copy(filePart, file)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	err = mw.Close()
-	if err != nil {
-		return nil, err
-	}
-
-	req := &http.Request{
-		Method: http.MethodPost,
-		URL:    wa.URL,
-		Header: http.Header{
-			"Content-Type": {mw.FormDataContentType()},
-		},
-		Body: ioutil.NopCloser(bodyBuf),
-	}
-
-	if ctx != nil {
-		req = req.WithContext(ctx)
-	}
-
-	res, err := wa.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer res.Body.Close()
-
-	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != 204 {
-		b, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(string(b))
-	}
-
-	if wa.Wait {
-		msg := new(Message)
-		err = jsoniter.NewDecoder(res.Body).Decode(msg)
-		if err != nil {
-			return nil, err
-		}
-
-		return msg, nil
-	}
-
-	return nil, nil
+	return wa.ExecuteWithAttachments(ctx, wep, []Attachment{{Filename: filename, Reader: file}})
 }
 
 // Webhook represents Webhook Structure
@@ -179,7 +181,7 @@ func (wa *WebhookAPI) Get(ctx context.Context) (*Webhook, error) {
 		req = req.WithContext(ctx)
 	}
 
-	res, err := wa.Client.Do(req)
+	res, err := wa.do(ctx, req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +220,8 @@ type WebhookModifyParams struct {
 func (wa *WebhookAPI) Modify(ctx context.Context, wmp *WebhookModifyParams) (*Webhook, error) {
 	bodyBuf := bytes.NewBuffer([]byte{})
 
-	err := jsoniter.NewEncoder(bodyBuf).Encode(wmp)
+	enc := wa.encoder()
+	err := enc.Encode(bodyBuf, wmp)
 	if err != nil {
 		return nil, err
 	}
@@ -226,16 +229,15 @@ func (wa *WebhookAPI) Modify(ctx context.Context, wmp *WebhookModifyParams) (*We
 	req := &http.Request{
 		Method: http.MethodPatch,
 		URL:    wa.URL,
-		Body:   ioutil.NopCloser(bodyBuf),
 		Header: http.Header{
-			"Content-Type": {"application/json"},
+			"Content-Type": {enc.ContentType()},
 		},
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	}
 
-	res, err := wa.Client.Do(req)
+	res, err := wa.do(ctx, req, bodyBuf.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -264,8 +266,11 @@ func (wa *WebhookAPI) Delete(ctx context.Context) error {
 		Method: http.MethodDelete,
 		URL:    wa.URL,
 	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
 
-	res, err := wa.Client.Do(req)
+	res, err := wa.do(ctx, req, nil)
 	if err != nil {
 		return err
 	}
@@ -280,6 +285,3 @@ func (wa *WebhookAPI) Delete(ctx context.Context) error {
 
 	return nil
 }
-
-// TODO: ExecuteSlack method
-// TODO: ExecuteGitHub method
@@ -0,0 +1,46 @@
+package discordhook
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// DataEncoder lets a WebhookAPI's outgoing JSON payloads be produced by a custom
+// encoder instead of the default jsoniter-based implementation.
+type DataEncoder interface {
+	// ContentType - the Content-Type header value to send with encoded requests
+	ContentType() string
+	// Encode writes v to w
+	Encode(w io.Writer, v interface{}) error
+	// FileExtension - the file extension (without a leading dot) matching this
+	// encoder's output, for callers that attach an encoded payload as a file
+	FileExtension() string
+}
+
+// jsoniterEncoder is the default DataEncoder, matching the library's existing wire format.
+type jsoniterEncoder struct{}
+
+// ContentType implements DataEncoder.
+func (jsoniterEncoder) ContentType() string {
+	return "application/json"
+}
+
+// Encode implements DataEncoder.
+func (jsoniterEncoder) Encode(w io.Writer, v interface{}) error {
+	return jsoniter.NewEncoder(w).Encode(v)
+}
+
+// FileExtension implements DataEncoder.
+func (jsoniterEncoder) FileExtension() string {
+	return "json"
+}
+
+// encoder returns wa.Encoder, falling back to the default jsoniter encoder.
+func (wa *WebhookAPI) encoder() DataEncoder {
+	if wa.Encoder != nil {
+		return wa.Encoder
+	}
+
+	return jsoniterEncoder{}
+}
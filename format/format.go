@@ -0,0 +1,206 @@
+// Package format renders arbitrary Go values and errors into Discord-ready
+// code-block strings, chunking the output to respect Discord's field,
+// content, and embed length limits.
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Quaver/discordhook"
+)
+
+const (
+	// FieldValueLimit is Discord's maximum length for a single embed field value.
+	FieldValueLimit = 1024
+	// ContentLimit is Discord's maximum length for a message's content.
+	ContentLimit = 2000
+	// EmbedTotalLimit is Discord's maximum combined length across all text in an embed.
+	EmbedTotalLimit = 6000
+	// defaultMaxFields is how many embed fields CodeBlockBuilder splits output
+	// across before falling back to an overflow attachment.
+	defaultMaxFields = 5
+)
+
+// DataEncoder turns an arbitrary Go value into its textual representation for
+// display inside a Discord code block.
+type DataEncoder interface {
+	// Encode renders v as text
+	Encode(v interface{}) (string, error)
+	// Language reports the code-block language tag to use, e.g. "json"
+	Language() string
+	// FileExtension reports the file extension (without a leading dot) to use
+	// for an overflow attachment, e.g. "json"
+	FileExtension() string
+}
+
+// JSONEncoder is the default DataEncoder, producing indented JSON.
+type JSONEncoder struct{}
+
+// Language implements DataEncoder.
+func (JSONEncoder) Language() string {
+	return "json"
+}
+
+// FileExtension implements DataEncoder.
+func (JSONEncoder) FileExtension() string {
+	return "json"
+}
+
+// Encode implements DataEncoder.
+func (JSONEncoder) Encode(v interface{}) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// CodeBlockBuilder renders Go values and errors as Discord-ready code blocks,
+// chunking the output across multiple embed fields - or, past MaxFields or
+// EmbedTotalLimit, an attached overflow file - to respect Discord's length
+// limits. A CodeBlockBuilder accumulates the combined size of every field it
+// has produced, so reuse one instance per embed (not per field) to keep the
+// whole embed under EmbedTotalLimit.
+type CodeBlockBuilder struct {
+	// Encoder - turns values into text; defaults to JSONEncoder{}
+	Encoder DataEncoder
+	// MaxFields - maximum number of embed fields to split output across before
+	// the remainder is returned as an overflow attachment instead; defaults to 5
+	MaxFields int
+
+	// total is the combined Name+Value length of every field built so far.
+	total int
+}
+
+func (b *CodeBlockBuilder) encoder() DataEncoder {
+	if b.Encoder != nil {
+		return b.Encoder
+	}
+
+	return JSONEncoder{}
+}
+
+func (b *CodeBlockBuilder) maxFields() int {
+	if b.MaxFields > 0 {
+		return b.MaxFields
+	}
+
+	return defaultMaxFields
+}
+
+// Build renders v via the configured Encoder, splitting it across up to
+// MaxFields embed fields named after `name`. If the rendered text doesn't fit
+// within that many fields, or would push the embed built so far past
+// EmbedTotalLimit, the remainder is instead returned as an overflow
+// *discordhook.Attachment the caller can pass to ExecuteWithAttachments.
+func (b *CodeBlockBuilder) Build(name string, v interface{}) ([]*discordhook.EmbedField, *discordhook.Attachment, error) {
+	text, err := b.encoder().Encode(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lang := b.encoder().Language()
+	fields, overflow := b.splitCodeBlock(name, text, lang)
+
+	return fields, overflowAttachment(name, b.encoder().FileExtension(), overflow), nil
+}
+
+// BuildError renders err's message as a plain-text code block, applying the
+// same field/attachment chunking as Build.
+func (b *CodeBlockBuilder) BuildError(name string, err error) ([]*discordhook.EmbedField, *discordhook.Attachment) {
+	fields, overflow := b.splitCodeBlock(name, err.Error(), "")
+
+	return fields, overflowAttachment(name, "txt", overflow)
+}
+
+// splitCodeBlock splits text into fenced code-block embed fields of at most
+// FieldValueLimit characters, stopping once MaxFields is reached or the next
+// field would push the embed's running total past EmbedTotalLimit, and
+// returning whatever text didn't fit as the overflow remainder.
+func (b *CodeBlockBuilder) splitCodeBlock(name, text, lang string) ([]*discordhook.EmbedField, string) {
+	fence := "```" + lang + "\n"
+	const closeFence = "\n```"
+	budget := FieldValueLimit - len(fence) - len(closeFence)
+
+	var fields []*discordhook.EmbedField
+	remaining := text
+
+	for len(remaining) > 0 && len(fields) < b.maxFields() {
+		part := remaining
+		if len(part) > budget {
+			part = remaining[:runeBoundary(remaining, budget)]
+		}
+
+		fname := fieldName(name, len(fields))
+		value := fence + part + closeFence
+
+		if b.total+len(fname)+len(value) > EmbedTotalLimit {
+			break
+		}
+
+		remaining = remaining[len(part):]
+		b.total += len(fname) + len(value)
+
+		fields = append(fields, &discordhook.EmbedField{Name: fname, Value: value})
+	}
+
+	return fields, remaining
+}
+
+// runeBoundary returns the largest byte offset <= n within s that doesn't
+// split a multi-byte UTF-8 rune, so slicing s[:runeBoundary(s, n)] never
+// produces invalid UTF-8.
+func runeBoundary(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return n
+}
+
+func fieldName(name string, index int) string {
+	if index == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s (cont. %d)", name, index+1)
+}
+
+// TruncateForContent trims s to fit within Discord's ContentLimit for a
+// message's `content` field, marking truncated output with a trailing ellipsis.
+func TruncateForContent(s string) string {
+	if len(s) <= ContentLimit {
+		return s
+	}
+
+	const ellipsis = "..."
+
+	return s[:ContentLimit-len(ellipsis)] + ellipsis
+}
+
+// overflowAttachment wraps remainder as a plain-text file attachment, or
+// returns nil if there's nothing left over.
+func overflowAttachment(name, ext string, remainder string) *discordhook.Attachment {
+	if remainder == "" {
+		return nil
+	}
+
+	if ext == "" {
+		ext = "txt"
+	}
+
+	return &discordhook.Attachment{
+		Filename:    strings.ReplaceAll(strings.ToLower(name), " ", "-") + "." + ext,
+		Description: name + " (truncated, see attachment)",
+		ContentType: "text/plain",
+		Reader:      strings.NewReader(remainder),
+	}
+}
@@ -0,0 +1,163 @@
+package format
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestCodeBlockBuilderBuildFitsInOneField(t *testing.T) {
+	b := &CodeBlockBuilder{}
+
+	fields, attachment, err := b.Build("payload", map[string]string{"hello": "world"})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(fields) != 1 {
+		t.Fatalf("len(fields) = %d, want 1", len(fields))
+	}
+
+	if fields[0].Name != "payload" {
+		t.Fatalf("fields[0].Name = %q, want %q", fields[0].Name, "payload")
+	}
+
+	if attachment != nil {
+		t.Fatal("expected no overflow attachment for a small payload")
+	}
+}
+
+func TestCodeBlockBuilderSplitsAcrossFields(t *testing.T) {
+	b := &CodeBlockBuilder{Encoder: plainEncoder{}}
+
+	text := strings.Repeat("x", 3000)
+	fields, attachment, err := b.Build("log", text)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(fields) < 2 {
+		t.Fatalf("len(fields) = %d, want at least 2 for a %d-char payload", len(fields), len(text))
+	}
+
+	for _, f := range fields {
+		if len(f.Value) > FieldValueLimit {
+			t.Fatalf("field %q value is %d chars, exceeds FieldValueLimit %d", f.Name, len(f.Value), FieldValueLimit)
+		}
+	}
+
+	if attachment != nil {
+		t.Fatal("expected no overflow attachment, text should fit within MaxFields")
+	}
+}
+
+func TestCodeBlockBuilderOverflowsToAttachment(t *testing.T) {
+	b := &CodeBlockBuilder{Encoder: plainEncoder{}, MaxFields: 1}
+
+	text := strings.Repeat("y", 3000)
+	fields, attachment, err := b.Build("log", text)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(fields) != 1 {
+		t.Fatalf("len(fields) = %d, want 1 (MaxFields: 1)", len(fields))
+	}
+
+	if attachment == nil {
+		t.Fatal("expected an overflow attachment once MaxFields was exceeded")
+	}
+
+	if attachment.Filename != "log.txt" {
+		t.Fatalf("attachment.Filename = %q, want %q", attachment.Filename, "log.txt")
+	}
+
+	b2, err := ioutil.ReadAll(attachment.Reader)
+	if err != nil {
+		t.Fatalf("reading attachment: %v", err)
+	}
+
+	if len(b2) == 0 {
+		t.Fatal("overflow attachment content is empty")
+	}
+}
+
+func TestCodeBlockBuilderEnforcesEmbedTotalLimit(t *testing.T) {
+	b := &CodeBlockBuilder{Encoder: plainEncoder{}}
+
+	text := strings.Repeat("z", 900)
+
+	var totalFields int
+	var lastAttachment bool
+
+	for i := 0; i < 10; i++ {
+		fields, attachment, err := b.Build("field", text)
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+
+		totalFields += len(fields)
+		if attachment != nil {
+			lastAttachment = true
+		}
+	}
+
+	if b.total > EmbedTotalLimit {
+		t.Fatalf("builder's running total is %d, exceeds EmbedTotalLimit %d", b.total, EmbedTotalLimit)
+	}
+
+	if !lastAttachment {
+		t.Fatal("expected building enough fields to eventually overflow to an attachment once EmbedTotalLimit was reached")
+	}
+}
+
+func TestCodeBlockBuilderBuildError(t *testing.T) {
+	b := &CodeBlockBuilder{}
+
+	fields, attachment := b.BuildError("error", errors.New("boom"))
+
+	if len(fields) != 1 {
+		t.Fatalf("len(fields) = %d, want 1", len(fields))
+	}
+
+	if !strings.Contains(fields[0].Value, "boom") {
+		t.Fatalf("field value %q does not contain the error message", fields[0].Value)
+	}
+
+	if attachment != nil {
+		t.Fatal("expected no overflow attachment for a short error message")
+	}
+}
+
+func TestRuneBoundaryDoesNotSplitMultiByteRunes(t *testing.T) {
+	s := strings.Repeat("日", 500) // each rune is 3 bytes
+
+	for n := 0; n <= len(s); n++ {
+		cut := runeBoundary(s, n)
+
+		if !utf8ValidPrefix(s, cut) {
+			t.Fatalf("runeBoundary(s, %d) = %d produced an invalid UTF-8 prefix", n, cut)
+		}
+	}
+}
+
+func utf8ValidPrefix(s string, n int) bool {
+	return n == len(s) || s[n] < 0x80 || s[n]>>6 != 0b10
+}
+
+// plainEncoder renders v as-is for tests, bypassing JSON quoting so string
+// lengths are easy to reason about.
+type plainEncoder struct{}
+
+func (plainEncoder) Encode(v interface{}) (string, error) {
+	return v.(string), nil
+}
+
+func (plainEncoder) Language() string {
+	return ""
+}
+
+func (plainEncoder) FileExtension() string {
+	return "txt"
+}
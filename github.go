@@ -0,0 +1,224 @@
+package discordhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// GitHubEvent identifies the GitHub webhook event being delivered, matching the
+// `X-GitHub-Event` header GitHub sends alongside the payload.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+type GitHubEvent string
+
+const (
+	// GitHubEventPush - a commit or tag was pushed
+	GitHubEventPush GitHubEvent = "push"
+	// GitHubEventIssues - activity related to an issue
+	GitHubEventIssues GitHubEvent = "issues"
+	// GitHubEventIssueComment - activity related to an issue or pull request comment
+	GitHubEventIssueComment GitHubEvent = "issue_comment"
+	// GitHubEventPullRequest - activity related to a pull request
+	GitHubEventPullRequest GitHubEvent = "pull_request"
+	// GitHubEventRelease - activity related to a release
+	GitHubEventRelease GitHubEvent = "release"
+	// GitHubEventStar - activity related to a repository star
+	GitHubEventStar GitHubEvent = "star"
+	// GitHubEventFork - a repository was forked
+	GitHubEventFork GitHubEvent = "fork"
+	// GitHubEventWatch - someone started watching (starred) a repository
+	GitHubEventWatch GitHubEvent = "watch"
+)
+
+// GitHubRepository is the subset of GitHub's repository object used across event payloads.
+type GitHubRepository struct {
+	// ID - the repository id
+	ID int64 `json:"id"`
+	// Name - the short repository name
+	Name string `json:"name"`
+	// FullName - the "owner/name" repository name
+	FullName string `json:"full_name"`
+	// HTMLURL - the repository's web url
+	HTMLURL string `json:"html_url"`
+	// Private - whether the repository is private
+	Private bool `json:"private"`
+}
+
+// GitHubUser is the subset of GitHub's user object used across event payloads.
+type GitHubUser struct {
+	// Login - the user's username
+	Login string `json:"login"`
+	// ID - the user id
+	ID int64 `json:"id"`
+	// AvatarURL - the user's avatar image url
+	AvatarURL string `json:"avatar_url"`
+	// HTMLURL - the user's profile web url
+	HTMLURL string `json:"html_url"`
+}
+
+// GitHubCommit is the subset of GitHub's commit object used in push event payloads.
+type GitHubCommit struct {
+	// ID - the commit sha
+	ID string `json:"id"`
+	// Message - the commit message
+	Message string `json:"message"`
+	// URL - the commit's web url
+	URL string `json:"url"`
+	// Author - the commit author
+	Author *GitHubCommitAuthor `json:"author"`
+}
+
+// GitHubCommitAuthor is the author/committer of a GitHubCommit.
+type GitHubCommitAuthor struct {
+	// Name - the author's display name
+	Name string `json:"name"`
+	// Email - the author's email address
+	Email string `json:"email"`
+	// Username - the author's GitHub username, if known
+	Username string `json:"username,omitempty"`
+}
+
+// GitHubPushEvent is the payload for the `push` event.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type GitHubPushEvent struct {
+	// Ref - the full git ref that was pushed
+	Ref string `json:"ref"`
+	// Before - the sha of the most recent commit before the push
+	Before string `json:"before"`
+	// After - the sha of the most recent commit after the push
+	After string `json:"after"`
+	// Repository - the repository that was pushed to
+	Repository *GitHubRepository `json:"repository"`
+	// Pusher - the user who pushed the commits
+	Pusher *GitHubCommitAuthor `json:"pusher"`
+	// Sender - the user who triggered the event
+	Sender *GitHubUser `json:"sender"`
+	// Commits - the commits pushed, limited to 20 by GitHub
+	Commits []*GitHubCommit `json:"commits"`
+}
+
+// GitHubIssue is the subset of GitHub's issue object used in issues and issue_comment events.
+type GitHubIssue struct {
+	// Number - the issue number
+	Number int `json:"number"`
+	// Title - the issue title
+	Title string `json:"title"`
+	// Body - the issue body
+	Body string `json:"body"`
+	// State - the issue state, e.g. "open" or "closed"
+	State string `json:"state"`
+	// HTMLURL - the issue's web url
+	HTMLURL string `json:"html_url"`
+	// User - the user who opened the issue
+	User *GitHubUser `json:"user"`
+}
+
+// GitHubIssuesEvent is the payload for the `issues` event.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#issues
+type GitHubIssuesEvent struct {
+	// Action - the action that was performed, e.g. "opened", "closed", "reopened"
+	Action string `json:"action"`
+	// Issue - the issue the action was performed on
+	Issue *GitHubIssue `json:"issue"`
+	// Repository - the repository the issue belongs to
+	Repository *GitHubRepository `json:"repository"`
+	// Sender - the user who triggered the event
+	Sender *GitHubUser `json:"sender"`
+}
+
+// GitHubPullRequest is the subset of GitHub's pull request object used in pull_request events.
+type GitHubPullRequest struct {
+	// Number - the pull request number
+	Number int `json:"number"`
+	// Title - the pull request title
+	Title string `json:"title"`
+	// Body - the pull request body
+	Body string `json:"body"`
+	// State - the pull request state, e.g. "open" or "closed"
+	State string `json:"state"`
+	// Merged - whether the pull request was merged
+	Merged bool `json:"merged"`
+	// HTMLURL - the pull request's web url
+	HTMLURL string `json:"html_url"`
+	// User - the user who opened the pull request
+	User *GitHubUser `json:"user"`
+}
+
+// GitHubPullRequestEvent is the payload for the `pull_request` event.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request
+type GitHubPullRequestEvent struct {
+	// Action - the action that was performed, e.g. "opened", "closed", "reopened"
+	Action string `json:"action"`
+	// Number - the pull request number
+	Number int `json:"number"`
+	// PullRequest - the pull request the action was performed on
+	PullRequest *GitHubPullRequest `json:"pull_request"`
+	// Repository - the repository the pull request belongs to
+	Repository *GitHubRepository `json:"repository"`
+	// Sender - the user who triggered the event
+	Sender *GitHubUser `json:"sender"`
+}
+
+// ExecuteGitHub - executes the GitHub-compatible webhook endpoint, posting a GitHub
+// webhook event payload as-is along with the `X-GitHub-Event` header Discord uses to
+// select how the event is rendered. `payload` is typically one of GitHubPushEvent,
+// GitHubIssuesEvent, or GitHubPullRequestEvent, but any value that marshals into the
+// expected shape for `event` may be passed through.
+// If `wait` was set to `true`, then the response will be expected and parsed in `msg`.
+// Return message, error
+func (wa *WebhookAPI) ExecuteGitHub(ctx context.Context, event GitHubEvent, payload interface{}) (*Message, error) {
+	bodyBuf := bytes.NewBuffer([]byte{})
+
+	enc := wa.encoder()
+	err := enc.Encode(bodyBuf, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *wa.URL
+	u.Path += "/github"
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &u,
+		Header: http.Header{
+			"Content-Type":   {enc.ContentType()},
+			"X-GitHub-Event": {string(event)},
+		},
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, bodyBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != 204 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(b))
+	}
+
+	if wa.Wait {
+		msg := new(Message)
+		err = jsoniter.NewDecoder(res.Body).Decode(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return msg, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,164 @@
+package discordhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/andersfylling/snowflake"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// WebhookMessageEditParams represents the payload for editing a previously sent
+// webhook message.
+// https://discord.com/developers/docs/resources/webhook#edit-webhook-message-jsonform-params
+type WebhookMessageEditParams struct {
+	// Content - the message contents (up to 2000 characters)
+	Content string `json:"content,omitempty"`
+	// Embeds - array of up to 10 embed objects
+	Embeds []*Embed `json:"embeds,omitempty"`
+	// AllowedMentions - allowed mentions for the message
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Attachments - descriptors for the message's attachments; omit an existing
+	// attachment here to remove it, or keep it by id to leave it untouched. An
+	// empty list is left out of the request, so it does not remove every attachment
+	Attachments []*MessageAttachment `json:"attachments,omitempty"`
+	// Components - message components such as buttons and select menus
+	Components []Component `json:"components,omitempty"`
+}
+
+// messageURL builds the URL for a webhook message sub-resource, preserving the
+// `wait` query parameter and adding `thread_id` when threadID is non-zero. This
+// gives EditMessage/DeleteMessage/GetMessage the same thread targeting that
+// executeURL gives ExecuteInThread.
+func (wa *WebhookAPI) messageURL(messageID snowflake.Snowflake, threadID snowflake.Snowflake) *url.URL {
+	u := *wa.URL
+	u.Path += "/messages/" + strconv.FormatUint(uint64(messageID), 10)
+
+	if threadID != 0 {
+		q := u.Query()
+		q.Set("thread_id", strconv.FormatUint(uint64(threadID), 10))
+		u.RawQuery = q.Encode()
+	}
+
+	return &u
+}
+
+// EditMessage - edits a previously sent webhook message. If threadID is non-zero,
+// the message is looked up within that thread.
+// Return message, error
+func (wa *WebhookAPI) EditMessage(ctx context.Context, messageID snowflake.Snowflake, params *WebhookMessageEditParams, threadID snowflake.Snowflake) (*Message, error) {
+	bodyBuf := bytes.NewBuffer([]byte{})
+
+	enc := wa.encoder()
+	err := enc.Encode(bodyBuf, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &http.Request{
+		Method: http.MethodPatch,
+		URL:    wa.messageURL(messageID, threadID),
+		Header: http.Header{
+			"Content-Type": {enc.ContentType()},
+		},
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, bodyBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(b))
+	}
+
+	msg := new(Message)
+	err = jsoniter.NewDecoder(res.Body).Decode(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// DeleteMessage - deletes a previously sent webhook message. If threadID is
+// non-zero, the message is looked up within that thread.
+func (wa *WebhookAPI) DeleteMessage(ctx context.Context, messageID snowflake.Snowflake, threadID snowflake.Snowflake) error {
+	req := &http.Request{
+		Method: http.MethodDelete,
+		URL:    wa.messageURL(messageID, threadID),
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 204 && res.StatusCode != 200 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		return errors.New(string(b))
+	}
+
+	return nil
+}
+
+// GetMessage - fetches a previously sent webhook message. If threadID is
+// non-zero, the message is looked up within that thread.
+// Return message, error
+func (wa *WebhookAPI) GetMessage(ctx context.Context, messageID snowflake.Snowflake, threadID snowflake.Snowflake) (*Message, error) {
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    wa.messageURL(messageID, threadID),
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(b))
+	}
+
+	msg := new(Message)
+	err = jsoniter.NewDecoder(res.Body).Decode(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,236 @@
+package discordhook
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitInfo describes the rate limit state observed from a Discord webhook
+// response, passed to the callback registered via Limiter.OnRateLimit.
+type RateLimitInfo struct {
+	// Limit - the number of requests allowed in the current rate limit bucket
+	Limit int
+	// Remaining - the number of requests remaining in the current bucket
+	Remaining int
+	// ResetAfter - how long until the bucket (or, if Global, the global limit) resets
+	ResetAfter time.Duration
+	// Global - whether this rate limit applies globally rather than to this bucket
+	Global bool
+}
+
+// Limiter tracks Discord's per-webhook rate limit bucket and blocks requests once
+// the bucket is exhausted, transparently retrying 429 responses using `Retry-After`.
+// Each webhook ID is its own bucket, so a Limiter should not be shared between
+// WebhookAPI values pointing at different webhooks. A Limiter is safe for
+// concurrent use by multiple goroutines sharing the same WebhookAPI.
+//
+// A WebhookAPI created via NewWebhookAPI has a Limiter enabled by default; set
+// WebhookAPI.Limiter to `nil` to disable it and plug in your own.
+type Limiter struct {
+	mu        sync.Mutex
+	hasBucket bool
+	remaining int
+	resetAt   time.Time
+
+	globalMu      sync.Mutex
+	globalResetAt time.Time
+
+	onRateLimit func(RateLimitInfo)
+}
+
+// NewLimiter creates a Limiter with no prior bucket state.
+func NewLimiter() *Limiter {
+	return &Limiter{}
+}
+
+// OnRateLimit registers a callback invoked whenever the bucket is exhausted or a
+// 429 response is received. It is called from whichever goroutine observed the
+// rate limit, so the callback should not block.
+func (l *Limiter) OnRateLimit(fn func(RateLimitInfo)) {
+	l.mu.Lock()
+	l.onRateLimit = fn
+	l.mu.Unlock()
+}
+
+// acquire blocks until any active global rate limit has passed and a slot in
+// the current bucket is available, then debits that slot before returning so
+// that concurrent callers are actually throttled against each other rather
+// than just against past responses. Call release if the request that
+// acquired the slot never reaches the server, so the slot isn't lost.
+func (l *Limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.globalMu.Lock()
+		globalWait := time.Until(l.globalResetAt)
+		l.globalMu.Unlock()
+
+		if globalWait > 0 {
+			if err := sleepCtx(ctx, globalWait); err != nil {
+				return err
+			}
+			continue
+		}
+
+		l.mu.Lock()
+
+		if l.hasBucket && l.remaining <= 0 {
+			if wait := time.Until(l.resetAt); wait > 0 {
+				l.mu.Unlock()
+				if err := sleepCtx(ctx, wait); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The bucket's reset window has passed locally but no response has
+			// refreshed it yet; treat it as reset until the next response says otherwise.
+			l.hasBucket = false
+		}
+
+		l.remaining--
+		l.mu.Unlock()
+
+		return nil
+	}
+}
+
+// release restores a slot debited by acquire, for a request that acquired one
+// but never actually counted against the server-side bucket (a failed send,
+// or a 429 - Discord does not debit the bucket for rejected requests).
+func (l *Limiter) release() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	l.remaining++
+	l.mu.Unlock()
+}
+
+// update records the rate limit bucket headers from a non-429 response.
+func (l *Limiter) update(header http.Header) {
+	if l == nil {
+		return
+	}
+
+	remaining, hasRemaining := parseHeaderInt(header.Get("X-RateLimit-Remaining"))
+	resetAfter, hasResetAfter := parseHeaderFloat(header.Get("X-RateLimit-Reset-After"))
+	if !hasRemaining || !hasResetAfter {
+		return
+	}
+
+	limit, _ := parseHeaderInt(header.Get("X-RateLimit-Limit"))
+	resetIn := time.Duration(resetAfter * float64(time.Second))
+
+	l.mu.Lock()
+	l.hasBucket = true
+	l.remaining = remaining
+	l.resetAt = time.Now().Add(resetIn)
+	cb := l.onRateLimit
+	l.mu.Unlock()
+
+	if remaining <= 0 && cb != nil {
+		cb(RateLimitInfo{Limit: limit, Remaining: remaining, ResetAfter: resetIn})
+	}
+}
+
+// defaultRetryAfter is the backoff used when a 429 response's `Retry-After`
+// header is missing or isn't in a format we can parse, so the retry loop in
+// `do` always backs off instead of spinning.
+const defaultRetryAfter = 1 * time.Second
+
+// handle429 records a 429 response (including a global exhaustion, per
+// `X-RateLimit-Scope`) and returns how long to wait before retrying.
+func (l *Limiter) handle429(header http.Header) time.Duration {
+	wait := parseRetryAfter(header)
+	global := header.Get("X-RateLimit-Scope") == "global"
+
+	if global {
+		l.globalMu.Lock()
+		l.globalResetAt = time.Now().Add(wait)
+		l.globalMu.Unlock()
+	}
+
+	l.mu.Lock()
+	cb := l.onRateLimit
+	l.mu.Unlock()
+
+	if cb != nil {
+		cb(RateLimitInfo{ResetAfter: wait, Global: global})
+	}
+
+	return wait
+}
+
+// sleepCtx sleeps for d, returning early with ctx.Err() if ctx is cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func parseHeaderInt(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func parseHeaderFloat(s string) (float64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// parseRetryAfter reads a `Retry-After` header, accepting both Discord's plain
+// delta-seconds form and the RFC 7231 HTTP-date form a proxy in front of
+// Discord (e.g. Cloudflare) may send instead. It falls back to
+// defaultRetryAfter when the header is absent, unparseable, or resolves to a
+// non-positive duration, so callers always back off rather than busy-retry.
+func parseRetryAfter(header http.Header) time.Duration {
+	s := header.Get("Retry-After")
+	if s == "" {
+		return defaultRetryAfter
+	}
+
+	if secs, ok := parseHeaderFloat(s); ok {
+		if d := time.Duration(secs * float64(time.Second)); d > 0 {
+			return d
+		}
+		return defaultRetryAfter
+	}
+
+	if t, err := http.ParseTime(s); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return defaultRetryAfter
+}
@@ -0,0 +1,165 @@
+package discordhook
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rateLimitHeader builds rate limit headers via Set so keys end up in their
+// canonical form, the way http.Transport populates them on a real response -
+// a literal http.Header{"X-RateLimit-Remaining": ...} map does not, and Get
+// would silently miss it.
+func rateLimitHeader(limit, remaining, resetAfter string) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", limit)
+	h.Set("X-RateLimit-Remaining", remaining)
+	h.Set("X-RateLimit-Reset-After", resetAfter)
+	return h
+}
+
+func TestLimiterAcquireReleaseRestoresSlot(t *testing.T) {
+	l := NewLimiter()
+	l.update(rateLimitHeader("1", "1", "60"))
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// The bucket is now exhausted (remaining debited to 0); release should
+	// restore the slot so a following acquire doesn't block on resetAt.
+	l.release()
+
+	done := make(chan error, 1)
+	go func() { done <- l.acquire(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("acquire after release: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire blocked despite release restoring the slot")
+	}
+}
+
+func TestLimiterAcquireBlocksUntilBucketResets(t *testing.T) {
+	l := NewLimiter()
+	l.update(rateLimitHeader("1", "1", "0.1"))
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	start := time.Now()
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("second acquire: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("second acquire returned after %v, expected it to wait out the reset window", elapsed)
+	}
+}
+
+func TestLimiterAcquireThrottlesConcurrentCallers(t *testing.T) {
+	l := NewLimiter()
+	l.update(rateLimitHeader("3", "3", "60"))
+
+	const callers = 3
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.acquire(context.Background()); err != nil {
+				t.Errorf("acquire: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	l.mu.Lock()
+	remaining := l.remaining
+	l.mu.Unlock()
+
+	if remaining != 0 {
+		t.Fatalf("remaining = %d, want 0 after %d concurrent acquires debited a bucket of %d", remaining, callers, callers)
+	}
+}
+
+func TestLimiterAcquireRespectsContextCancellation(t *testing.T) {
+	l := NewLimiter()
+	l.update(rateLimitHeader("1", "1", "60"))
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.acquire(ctx); err != ctx.Err() {
+		t.Fatalf("acquire on a cancelled context returned %v, want %v", err, ctx.Err())
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "2.5")
+
+	got := parseRetryAfter(header)
+	want := 2500 * time.Millisecond
+
+	if got != want {
+		t.Fatalf("parseRetryAfter(%q) = %v, want %v", "2.5", got, want)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(3 * time.Second).UTC()
+	header := http.Header{}
+	header.Set("Retry-After", when.Format(http.TimeFormat))
+
+	got := parseRetryAfter(header)
+
+	if got <= 0 || got > 4*time.Second {
+		t.Fatalf("parseRetryAfter(HTTP-date) = %v, want roughly 3s", got)
+	}
+}
+
+func TestParseRetryAfterFallsBackToDefault(t *testing.T) {
+	cases := []string{"", "not-a-duration", "-5"}
+
+	for _, s := range cases {
+		header := http.Header{}
+		if s != "" {
+			header.Set("Retry-After", s)
+		}
+
+		if got := parseRetryAfter(header); got != defaultRetryAfter {
+			t.Errorf("parseRetryAfter(%q) = %v, want defaultRetryAfter (%v)", s, got, defaultRetryAfter)
+		}
+	}
+}
+
+func TestLimiterHandle429SetsGlobalResetAt(t *testing.T) {
+	l := NewLimiter()
+	header := http.Header{}
+	header.Set("Retry-After", "1")
+	header.Set("X-RateLimit-Scope", "global")
+
+	wait := l.handle429(header)
+	if wait != time.Second {
+		t.Fatalf("handle429 returned %v, want 1s", wait)
+	}
+
+	l.globalMu.Lock()
+	globalWait := time.Until(l.globalResetAt)
+	l.globalMu.Unlock()
+
+	if globalWait <= 0 {
+		t.Fatal("handle429 with a global scope did not set globalResetAt in the future")
+	}
+}
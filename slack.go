@@ -0,0 +1,134 @@
+package discordhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SlackWebhookPayload represents the Slack incoming-webhook payload structure.
+// Discord's webhook endpoints accept this format directly via the `/slack` sub-path,
+// letting alerting pipelines that already emit Slack payloads post to Discord unchanged.
+// https://discord.com/developers/docs/resources/webhook#execute-slackcompatible-webhook
+type SlackWebhookPayload struct {
+	// Text - the message text
+	Text string `json:"text,omitempty"`
+	// Username - override the default username of the webhook
+	Username string `json:"username,omitempty"`
+	// IconURL - override the default avatar of the webhook with an image url
+	IconURL string `json:"icon_url,omitempty"`
+	// IconEmoji - override the default avatar of the webhook with an emoji
+	IconEmoji string `json:"icon_emoji,omitempty"`
+	// UnfurlLinks - whether Slack should unfurl links found in the text
+	UnfurlLinks bool `json:"unfurl_links,omitempty"`
+	// Attachments - array of Slack attachment objects
+	Attachments []*SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment represents a Slack message attachment.
+// https://api.slack.com/reference/messaging/attachments
+type SlackAttachment struct {
+	// Fallback - a plain-text summary used in notifications
+	Fallback string `json:"fallback,omitempty"`
+	// Color - color of the attachment's left border, e.g. "#ff0000" or "good"
+	Color string `json:"color,omitempty"`
+	// Pretext - text that appears above the attachment block
+	Pretext string `json:"pretext,omitempty"`
+	// AuthorName - small text used to display the author's name
+	AuthorName string `json:"author_name,omitempty"`
+	// AuthorLink - a valid URL that hyperlinks the AuthorName
+	AuthorLink string `json:"author_link,omitempty"`
+	// AuthorIcon - a valid URL for a small image next to the AuthorName
+	AuthorIcon string `json:"author_icon,omitempty"`
+	// Title - title of the attachment
+	Title string `json:"title,omitempty"`
+	// TitleLink - a valid URL that hyperlinks the Title
+	TitleLink string `json:"title_link,omitempty"`
+	// Text - the main body text of the attachment
+	Text string `json:"text,omitempty"`
+	// Fields - array of attachment fields
+	Fields []*SlackAttachmentField `json:"fields,omitempty"`
+	// ImageURL - a valid URL to an image to display inside the attachment
+	ImageURL string `json:"image_url,omitempty"`
+	// ThumbURL - a valid URL to an image to display as a thumbnail
+	ThumbURL string `json:"thumb_url,omitempty"`
+	// Footer - footer text
+	Footer string `json:"footer,omitempty"`
+	// FooterIcon - a valid URL for a small image beside the footer text
+	FooterIcon string `json:"footer_icon,omitempty"`
+	// Ts - an integer Unix timestamp rendered as the attachment's date
+	Ts int64 `json:"ts,omitempty"`
+	// MrkdwnIn - list of fields ("pretext", "text", "fields") rendered with Slack markdown
+	MrkdwnIn []string `json:"mrkdwn_in,omitempty"`
+}
+
+// SlackAttachmentField represents a single field of a SlackAttachment.
+// https://api.slack.com/reference/messaging/attachments#fields
+type SlackAttachmentField struct {
+	// Title - the field name
+	Title string `json:"title,omitempty"`
+	// Value - the field text, may contain standard message markup
+	Value string `json:"value,omitempty"`
+	// Short - whether the field should be displayed side-by-side with other fields
+	Short bool `json:"short,omitempty"`
+}
+
+// ExecuteSlack - executes the Slack-compatible webhook endpoint, posting a Slack
+// incoming-webhook payload as-is. If `wait` was set to `true`, then the response
+// will be expected and parsed in `msg`.
+// Return message, error
+func (wa *WebhookAPI) ExecuteSlack(ctx context.Context, payload *SlackWebhookPayload) (*Message, error) {
+	bodyBuf := bytes.NewBuffer([]byte{})
+
+	enc := wa.encoder()
+	err := enc.Encode(bodyBuf, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *wa.URL
+	u.Path += "/slack"
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &u,
+		Header: http.Header{
+			"Content-Type": {enc.ContentType()},
+		},
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := wa.do(ctx, req, bodyBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != 204 {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(b))
+	}
+
+	if wa.Wait {
+		msg := new(Message)
+		err = jsoniter.NewDecoder(res.Body).Decode(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return msg, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+package discordhook
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/andersfylling/snowflake"
+)
+
+// executeURL builds the URL for the base execute endpoint, preserving the
+// `wait` query parameter and adding `thread_id` when threadID is non-zero.
+func (wa *WebhookAPI) executeURL(threadID snowflake.Snowflake) *url.URL {
+	u := *wa.URL
+
+	if threadID != 0 {
+		q := u.Query()
+		q.Set("thread_id", strconv.FormatUint(uint64(threadID), 10))
+		u.RawQuery = q.Encode()
+	}
+
+	return &u
+}
+
+// ExecuteInThread - executes webhook into an existing thread, identified by
+// threadID, rebuilding the URL with `thread_id=` while preserving `wait=`.
+// EditMessage, DeleteMessage, and GetMessage already accept the same threadID
+// targeting via messageURL.
+// If `wait` was set to `true`, then the response will be expected and parsed
+// in `msg`.
+// Return message, error
+func (wa *WebhookAPI) ExecuteInThread(ctx context.Context, threadID snowflake.Snowflake, wep *WebhookExecuteParams, attachments ...Attachment) (*Message, error) {
+	return wa.execute(ctx, wa.executeURL(threadID), wep, attachments)
+}